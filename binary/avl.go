@@ -0,0 +1,301 @@
+package binary
+
+// An avlNode is a node in an AVLTree. It is augmented with a height,
+// which is used to keep the tree balanced as items are inserted and
+// removed.
+type avlNode struct {
+	// left and right are pointers to the children of the avlNode.
+	left, right *avlNode
+
+	// value contains the Value for this node.
+	value Interface
+
+	// height is the height of the subtree rooted at this node. A leaf
+	// has a height of 1; a nil node has a height of 0.
+	height int8
+}
+
+// An AVLTree is a self-balancing binary search tree. Unlike Tree, an
+// AVLTree performs AVL rotations after every Insert and Remove so that
+// the height of the tree never exceeds roughly 1.44*log2(n). This keeps
+// Contains, Get, Insert, and Remove at O(log n) even when items are
+// inserted in sorted order, which would otherwise degrade a Tree to a
+// linked list.
+type AVLTree struct {
+	root *avlNode
+}
+
+// avlTreeHeight returns the height of the subtree rooted at n, treating
+// a nil node as having a height of 0.
+func avlTreeHeight(n *avlNode) int8 {
+	if n == nil {
+		return 0
+	}
+
+	return n.height
+}
+
+// avlMax8 returns the larger of a and b.
+func avlMax8(a, b int8) int8 {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+// updateHeight recomputes n's height from the heights of its children.
+func updateHeight(n *avlNode) {
+	n.height = 1 + avlMax8(avlTreeHeight(n.left), avlTreeHeight(n.right))
+}
+
+// balanceFactor returns the difference between the heights of n's left
+// and right subtrees. A tree is AVL-balanced at n as long as this value
+// is within [-1, 1].
+func balanceFactor(n *avlNode) int8 {
+	return avlTreeHeight(n.left) - avlTreeHeight(n.right)
+}
+
+// rotateRight performs a right rotation around n and returns the new
+// root of the subtree.
+func rotateRight(n *avlNode) *avlNode {
+	var l = n.left
+	n.left = l.right
+	l.right = n
+
+	updateHeight(n)
+	updateHeight(l)
+
+	return l
+}
+
+// rotateLeft performs a left rotation around n and returns the new root
+// of the subtree.
+func rotateLeft(n *avlNode) *avlNode {
+	var r = n.right
+	n.right = r.left
+	r.left = n
+
+	updateHeight(n)
+	updateHeight(r)
+
+	return r
+}
+
+// rebalance recomputes n's height and, if n has become unbalanced,
+// performs the rotation(s) needed to restore the AVL invariant. It
+// returns the (possibly new) root of the subtree.
+func rebalance(n *avlNode) *avlNode {
+	updateHeight(n)
+
+	switch b := balanceFactor(n); {
+	case b > 1:
+		if balanceFactor(n.left) < 0 {
+			n.left = rotateLeft(n.left)
+		}
+		return rotateRight(n)
+	case b < -1:
+		if balanceFactor(n.right) > 0 {
+			n.right = rotateRight(n.right)
+		}
+		return rotateLeft(n)
+	default:
+		return n
+	}
+}
+
+// avlInsert inserts item into the subtree rooted at n, assuming item
+// does not already exist in the subtree, and returns the (possibly new)
+// root of the rebalanced subtree.
+func avlInsert(n *avlNode, item Interface) *avlNode {
+	if n == nil {
+		return &avlNode{value: item, height: 1}
+	}
+
+	if item.Compare(n.value.Value()) < EQ {
+		n.left = avlInsert(n.left, item)
+	} else {
+		n.right = avlInsert(n.right, item)
+	}
+
+	return rebalance(n)
+}
+
+// avlRemove removes item from the subtree rooted at n (if it exists)
+// and returns the (possibly new) root of the rebalanced subtree.
+func avlRemove(n *avlNode, item Interface) *avlNode {
+	if n == nil {
+		return nil
+	}
+
+	switch result := item.Compare(n.value.Value()); {
+	case result < EQ:
+		n.left = avlRemove(n.left, item)
+		return rebalance(n)
+	case result > EQ:
+		n.right = avlRemove(n.right, item)
+		return rebalance(n)
+	}
+
+	// We've found the node to remove. If it has at most one child, we
+	// can simply replace it with that child (or nil).
+	if n.left == nil {
+		return n.right
+	}
+	if n.right == nil {
+		return n.left
+	}
+
+	// Otherwise, we replace this node's value with its in-order
+	// successor - the left-most node of its right subtree - and then
+	// remove the successor from the right subtree.
+	var succ = n.right
+	for succ.left != nil {
+		succ = succ.left
+	}
+
+	n.value = succ.value
+	n.right = avlRemove(n.right, succ.value)
+
+	return rebalance(n)
+}
+
+// find locates the Interface stored in the tree that matches item, if
+// any.
+func (t *AVLTree) find(item Interface) (found bool, value Interface) {
+	var cur = t.root
+
+	for cur != nil {
+		switch result := item.Compare(cur.value.Value()); {
+		case result < EQ:
+			cur = cur.left
+		case result > EQ:
+			cur = cur.right
+		default:
+			return true, cur.value
+		}
+	}
+
+	return false, nil
+}
+
+// Contains searches the tree for a given item and returns true if it is
+// found.
+func (t *AVLTree) Contains(item Interface) bool {
+	found, _ := t.find(item)
+	return found
+}
+
+// Get retrieves an item from the tree.
+// If the item is not in the tree, it will return nil instead.
+func (t *AVLTree) Get(item Interface) interface{} {
+	if found, value := t.find(item); found {
+		return value.Value()
+	}
+
+	return nil
+}
+
+// Insert adds an item to the tree if it does not exist in the tree
+// already, rebalancing the tree as needed to keep it AVL-balanced.
+// If there is already an item in the tree that matches the one you are
+// adding, the tree will call Update on the existing item, passing in
+// the value of the item you are trying to add. To facilitate easily
+// inserting a chain of items, the method returns the tree after having
+// inserted an item.
+//
+// Note this is not a byte-for-byte match of Tree.Insert's behavior
+// when the matched node has two children and Update changes its key:
+// Tree.removeNode's two-children case overwrites the node being
+// removed with its in-order successor's value before Tree's own
+// chain-reinsert logic gets a chance to reuse it, so the value Tree
+// ends up reinserting is whatever that overwrite left behind, not
+// necessarily the one Update produced. t.find above hands Insert a
+// value decoupled from the node avlRemove splices out, so no such
+// overwrite happens here, and Insert always reinserts exactly the
+// value Update produced. See TestAVLTree_InsertWithTwoChildrenCollision
+// for a worked example of the two behaviors diverging.
+func (t *AVLTree) Insert(item Interface) *AVLTree {
+	if found, value := t.find(item); found {
+		// We have to remove the node before calling Update: Update may
+		// change value's key, and once it does, item can no longer be
+		// used to retrace the path to value's (still old) position in
+		// the tree. Removing first, while the tree still agrees with
+		// item about where value lives, sidesteps that.
+		t.root = avlRemove(t.root, item)
+		value.Update(item.Value())
+
+		// value may now belong wherever its new key sorts, which could
+		// collide with a different node already there - recursing
+		// through Insert (rather than inserting directly) lets that
+		// collision merge the same way the original one did.
+		return t.Insert(value)
+	}
+
+	t.root = avlInsert(t.root, item)
+
+	return t
+}
+
+// Remove deletes the specified item from the tree (if it exists in the
+// tree), rebalances the tree, and returns the tree.
+func (t *AVLTree) Remove(item Interface) *AVLTree {
+	t.root = avlRemove(t.root, item)
+	return t
+}
+
+// avlVisitInOrder is used internally to visit all of the nodes of a
+// subtree in order, i.e. LVR.
+func avlVisitInOrder(with VisitorFunc, subtreeRoot *avlNode) bool {
+	if subtreeRoot == nil {
+		return Continue
+	}
+
+	if Done == avlVisitInOrder(with, subtreeRoot.left) {
+		return Done
+	}
+
+	if Done == with(subtreeRoot.value.Value()) {
+		return Done
+	}
+
+	return avlVisitInOrder(with, subtreeRoot.right)
+}
+
+// VisitInOrder visits the nodes of an AVLTree in order.
+// At each node, it applies the visitorFunc to the
+// value of the node. VisitInOrder stops visiting
+// when the VisitorFunc returns Done ("true") after
+// visiting the node.
+func (t *AVLTree) VisitInOrder(with VisitorFunc) *AVLTree {
+	_ = avlVisitInOrder(with, t.root)
+	return t
+}
+
+// avlVisitInReverse is used internally to visit all the nodes of a
+// subtree in reverse order, i.e. RVL.
+func avlVisitInReverse(with VisitorFunc, subtreeRoot *avlNode) bool {
+	if subtreeRoot == nil {
+		return Continue
+	}
+
+	if Done == avlVisitInReverse(with, subtreeRoot.right) {
+		return Done
+	}
+
+	if Done == with(subtreeRoot.value.Value()) {
+		return Done
+	}
+
+	return avlVisitInReverse(with, subtreeRoot.left)
+}
+
+// VisitInReverse visits the nodes of an AVLTree in postfix or reverse
+// order. At each node, it applies the visitorFunc to the
+// value of the node. VisitInReverse stops visiting
+// when the VisitorFunc returns Done ("true") after
+// visiting the node.
+func (t *AVLTree) VisitInReverse(with VisitorFunc) *AVLTree {
+	_ = avlVisitInReverse(with, t.root)
+	return t
+}
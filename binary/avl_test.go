@@ -0,0 +1,198 @@
+package binary
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func SampleAVLTree() *AVLTree {
+	return new(AVLTree).
+		Insert(String("M", false)).
+		Insert(String("L", false)).
+		Insert(String("R", false))
+}
+
+func TestAVLTree_VisitInOrder(t *testing.T) {
+	var buf = new(bytes.Buffer)
+	SampleAVLTree().VisitInOrder(PrintNodeTo(buf))
+
+	want := "L\nM\nR\n"
+	got := buf.String()
+
+	assert.Equal(t, want, got)
+}
+
+func TestAVLTree_VisitInReverse(t *testing.T) {
+	var buf = new(bytes.Buffer)
+	SampleAVLTree().VisitInReverse(PrintNodeTo(buf))
+
+	want := "R\nM\nL\n"
+	got := buf.String()
+
+	assert.Equal(t, want, got)
+}
+
+func TestAVLTree_Contains(t *testing.T) {
+	tt := []struct {
+		name string
+		arg  string
+		want bool
+	}{
+		{"Exists", "L", true},
+		{"Not Exists", "Foo", false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var want = tc.want
+			var got = SampleAVLTree().Contains(String(tc.arg, false))
+
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestAVLTree_Get(t *testing.T) {
+	tt := []struct {
+		name string
+		arg  string
+		want interface{}
+	}{
+		{"Exists", "R", "R"},
+		{"Not Exists", "Foo", nil},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var want = tc.want
+			var got = SampleAVLTree().Get(String(tc.arg, true))
+
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestAVLTree_Insert_UpdatesExistingNode(t *testing.T) {
+	var want, got = 1, 0
+	new(AVLTree).
+		Insert(
+			Generic(
+				0,
+				func(this, to interface{}) int {
+					return EQ
+				},
+				func(this, with interface{}) interface{} {
+					got++
+					return this
+				},
+			)).
+		Insert(Int(0))
+
+	assert.Equal(t, want, got)
+}
+
+func TestAVLTree_Remove(t *testing.T) {
+	tt := []struct {
+		name string
+		arg  string
+		want string
+	}{
+		{"Left", "L", "M\nQ\nR\nT\n"},
+		{"Right", "R", "L\nM\nQ\nT\n"},
+		{"Root", "M", "L\nQ\nR\nT\n"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf = new(bytes.Buffer)
+			new(AVLTree).
+				Insert(String("L", false)).
+				Insert(String("M", false)).
+				Insert(String("R", false)).
+				Insert(String("T", false)).
+				Insert(String("Q", false)).
+				Remove(String(tc.arg, false)).
+				VisitInOrder(PrintNodeTo(buf))
+
+			var want, got = tc.want, buf.String()
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestAVLTree_InsertChainOfUpdates(t *testing.T) {
+	var comparer = func(this, to interface{}) int {
+		return this.(int) - to.(int)
+	}
+
+	var updater = func(this, with interface{}) interface{} {
+		return this.(int) + 1
+	}
+
+	var item = func(i int) *InterfaceImpl {
+		return Generic(i, comparer, updater)
+	}
+
+	var buf = new(bytes.Buffer)
+	var tree = new(AVLTree).
+		Insert(item(0)).
+		Insert(item(1)).
+		Insert(item(2)).
+		VisitInOrder(PrintNodeTo(buf))
+
+	var want, got = "0\n1\n2\n", buf.String()
+	require.Equal(t, want, got)
+
+	buf = new(bytes.Buffer)
+	tree = tree.Insert(item(0)).VisitInOrder(PrintNodeTo(buf))
+
+	want, got = "3\n", buf.String()
+	assert.Equal(t, want, got)
+}
+
+// TestAVLTree_InsertWithTwoChildrenCollision pins down how AVLTree
+// handles an update that changes the key of a node with two children,
+// where the new key collides with a value already in the tree. See
+// the note on AVLTree.Insert: this is a deliberate, documented
+// divergence from Tree.Insert, which - because of a quirk in
+// Tree.removeNode's two-children case - ends up merging 9, 13, 5, 9
+// into {5, 26} for the same accumulating updater.
+func TestAVLTree_InsertWithTwoChildrenCollision(t *testing.T) {
+	var accumulate = func(this, with interface{}) interface{} {
+		return this.(int) + with.(int)
+	}
+
+	var item = func(i int) *InterfaceImpl {
+		return Generic(i, func(this, to interface{}) int {
+			return this.(int) - to.(int)
+		}, accumulate)
+	}
+
+	var buf = new(bytes.Buffer)
+	new(AVLTree).
+		Insert(item(9)).
+		Insert(item(13)).
+		Insert(item(5)).
+		Insert(item(9)).
+		VisitInOrder(PrintNodeTo(buf))
+
+	assert.Equal(t, "5\n13\n18\n", buf.String())
+}
+
+func TestAVLTree_InsertInSortedOrderStaysBalanced(t *testing.T) {
+	const n = 1000
+
+	var tree = new(AVLTree)
+	for i := 0; i < n; i++ {
+		tree.Insert(Int(i))
+	}
+
+	var maxHeight = 1.44 * math.Log2(float64(n))
+	var got = float64(tree.root.height)
+
+	assert.LessOrEqual(t, got, maxHeight)
+}
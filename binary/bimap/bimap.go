@@ -0,0 +1,189 @@
+// Package bimap implements a bidirectional map on top of binary.Tree.
+package bimap
+
+import "matthew-burr/trees/binary"
+
+// A pair is the unit of storage shared by a BiMap's forward and inverse
+// trees: the forward tree orders pairs by key, the inverse tree orders
+// the very same pairs by value.
+type pair struct {
+	key, value interface{}
+}
+
+// byKey adapts cmp, a comparer over bare keys, into a CompareFunc that
+// also accepts a pair on either side - so it can compare two pairs
+// already in the forward tree, or a bare key being looked up against a
+// pair already there.
+func byKey(cmp binary.CompareFunc) binary.CompareFunc {
+	return func(this, to interface{}) int {
+		if p, ok := this.(pair); ok {
+			this = p.key
+		}
+		if p, ok := to.(pair); ok {
+			to = p.key
+		}
+		return cmp(this, to)
+	}
+}
+
+// byValue is byKey's counterpart for the inverse tree.
+func byValue(cmp binary.CompareFunc) binary.CompareFunc {
+	return func(this, to interface{}) int {
+		if p, ok := this.(pair); ok {
+			this = p.value
+		}
+		if p, ok := to.(pair); ok {
+			to = p.value
+		}
+		return cmp(this, to)
+	}
+}
+
+// A BiMap is a one-to-one mapping between keys and values. It is
+// backed by two binary.Trees - a forward tree ordered by key and an
+// inverse tree ordered by value - so that both Get and GetKey run in
+// O(log n), the same as a single binary.Tree's Get.
+type BiMap struct {
+	forward, inverse         *binary.Tree
+	keyCompare, valueCompare binary.CompareFunc
+	size                     int
+}
+
+// New returns an empty BiMap. keyCompare orders the forward tree and
+// valueCompare orders the inverse one, mirroring how binary.Generic
+// accepts a CompareFunc for the value it wraps.
+func New(keyCompare, valueCompare binary.CompareFunc) *BiMap {
+	if keyCompare == nil {
+		panic("you must provide a key comparer")
+	}
+	if valueCompare == nil {
+		panic("you must provide a value comparer")
+	}
+
+	return &BiMap{
+		forward:      new(binary.Tree),
+		inverse:      new(binary.Tree),
+		keyCompare:   keyCompare,
+		valueCompare: valueCompare,
+	}
+}
+
+// keySearch wraps a bare key for looking it up in the forward tree.
+func (m *BiMap) keySearch(key interface{}) *binary.InterfaceImpl {
+	return binary.Generic(key, byKey(m.keyCompare), nil)
+}
+
+// valueSearch wraps a bare value for looking it up in the inverse tree.
+func (m *BiMap) valueSearch(value interface{}) *binary.InterfaceImpl {
+	return binary.Generic(value, byValue(m.valueCompare), nil)
+}
+
+// keyItem wraps p for storage in the forward tree.
+func (m *BiMap) keyItem(p pair) *binary.InterfaceImpl {
+	return binary.Generic(p, byKey(m.keyCompare), nil)
+}
+
+// valueItem wraps p for storage in the inverse tree.
+func (m *BiMap) valueItem(p pair) *binary.InterfaceImpl {
+	return binary.Generic(p, byValue(m.valueCompare), nil)
+}
+
+// Size returns the number of key/value mappings in the BiMap.
+func (m *BiMap) Size() int {
+	return m.size
+}
+
+// Get retrieves the value mapped to key. The second return value
+// reports whether key was found.
+func (m *BiMap) Get(key interface{}) (value interface{}, ok bool) {
+	var raw = m.forward.Get(m.keySearch(key))
+	if raw == nil {
+		return nil, false
+	}
+
+	return raw.(pair).value, true
+}
+
+// GetKey retrieves the key mapped to value. The second return value
+// reports whether value was found.
+func (m *BiMap) GetKey(value interface{}) (key interface{}, ok bool) {
+	var raw = m.inverse.Get(m.valueSearch(value))
+	if raw == nil {
+		return nil, false
+	}
+
+	return raw.(pair).key, true
+}
+
+// remove deletes the (key, value) mapping from both trees.
+func (m *BiMap) remove(key, value interface{}) {
+	m.forward.Remove(m.keySearch(key))
+	m.inverse.Remove(m.valueSearch(value))
+	m.size--
+}
+
+// Put maps key to value, overwriting any mapping that already exists
+// for either key or value so the BiMap stays one-to-one. Because
+// Tree.Insert merges into a matching node with Update rather than
+// replacing it outright, Put can't just Insert into both trees and
+// trust them to stay consistent - it has to explicitly Remove whatever
+// was there first.
+func (m *BiMap) Put(key, value interface{}) *BiMap {
+	if oldValue, ok := m.Get(key); ok {
+		m.remove(key, oldValue)
+	}
+
+	if oldKey, ok := m.GetKey(value); ok {
+		m.remove(oldKey, value)
+	}
+
+	var p = pair{key: key, value: value}
+	m.forward.Insert(m.keyItem(p))
+	m.inverse.Insert(m.valueItem(p))
+	m.size++
+
+	return m
+}
+
+// Remove deletes the mapping for key, if any, and returns the BiMap.
+func (m *BiMap) Remove(key interface{}) *BiMap {
+	if value, ok := m.Get(key); ok {
+		m.remove(key, value)
+	}
+
+	return m
+}
+
+// RemoveValue deletes the mapping for value, if any, and returns the
+// BiMap.
+func (m *BiMap) RemoveValue(value interface{}) *BiMap {
+	if key, ok := m.GetKey(value); ok {
+		m.remove(key, value)
+	}
+
+	return m
+}
+
+// VisitByKey visits each (key, value) mapping in ascending order of
+// key. It applies visit to each pair in turn, stopping when visit
+// returns binary.Done ("true") after visiting a pair.
+func (m *BiMap) VisitByKey(visit func(key, value interface{}) bool) *BiMap {
+	m.forward.VisitInOrder(func(raw interface{}) bool {
+		var p = raw.(pair)
+		return visit(p.key, p.value)
+	})
+
+	return m
+}
+
+// VisitByValue visits each (key, value) mapping in ascending order of
+// value. It applies visit to each pair in turn, stopping when visit
+// returns binary.Done ("true") after visiting a pair.
+func (m *BiMap) VisitByValue(visit func(key, value interface{}) bool) *BiMap {
+	m.inverse.VisitInOrder(func(raw interface{}) bool {
+		var p = raw.(pair)
+		return visit(p.key, p.value)
+	})
+
+	return m
+}
@@ -0,0 +1,179 @@
+package bimap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func intCompare(this, to interface{}) int {
+	return this.(int) - to.(int)
+}
+
+func sampleBiMap() *BiMap {
+	return New(intCompare, func(this, to interface{}) int {
+		var a, b = this.(string), to.(string)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		default:
+			return 0
+		}
+	}).
+		Put(1, "one").
+		Put(2, "two").
+		Put(3, "three")
+}
+
+func TestNew_PanicsIfKeyCompareIsNil(t *testing.T) {
+	assert.Panics(t, func() {
+		New(nil, intCompare)
+	})
+}
+
+func TestNew_PanicsIfValueCompareIsNil(t *testing.T) {
+	assert.Panics(t, func() {
+		New(intCompare, nil)
+	})
+}
+
+func TestBiMap_Get(t *testing.T) {
+	tt := []struct {
+		name      string
+		arg       int
+		wantValue interface{}
+		wantOK    bool
+	}{
+		{"Exists", 1, "one", true},
+		{"Not Exists", 4, nil, false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var value, ok = sampleBiMap().Get(tc.arg)
+			assert.Equal(t, tc.wantValue, value)
+			assert.Equal(t, tc.wantOK, ok)
+		})
+	}
+}
+
+func TestBiMap_GetKey(t *testing.T) {
+	tt := []struct {
+		name    string
+		arg     string
+		wantKey interface{}
+		wantOK  bool
+	}{
+		{"Exists", "two", 2, true},
+		{"Not Exists", "four", nil, false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var key, ok = sampleBiMap().GetKey(tc.arg)
+			assert.Equal(t, tc.wantKey, key)
+			assert.Equal(t, tc.wantOK, ok)
+		})
+	}
+}
+
+func TestBiMap_Size(t *testing.T) {
+	var m = sampleBiMap()
+	assert.Equal(t, 3, m.Size())
+}
+
+func TestBiMap_Put_OverwritesExistingKey(t *testing.T) {
+	var m = sampleBiMap().Put(1, "uno")
+
+	var value, ok = m.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "uno", value)
+
+	_, ok = m.GetKey("one")
+	assert.False(t, ok)
+
+	assert.Equal(t, 3, m.Size())
+}
+
+func TestBiMap_Put_OverwritesExistingValue(t *testing.T) {
+	var m = sampleBiMap().Put(4, "one")
+
+	var key, ok = m.GetKey("one")
+	assert.True(t, ok)
+	assert.Equal(t, 4, key)
+
+	_, ok = m.Get(1)
+	assert.False(t, ok)
+
+	assert.Equal(t, 3, m.Size())
+}
+
+func TestBiMap_Put_OverwritesBothSidesAtOnce(t *testing.T) {
+	var m = sampleBiMap().Put(1, "two")
+
+	var value, ok = m.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "two", value)
+
+	_, ok = m.Get(2)
+	assert.False(t, ok)
+
+	_, ok = m.GetKey("one")
+	assert.False(t, ok)
+
+	assert.Equal(t, 2, m.Size())
+}
+
+func TestBiMap_Remove(t *testing.T) {
+	var m = sampleBiMap().Remove(2)
+
+	_, ok := m.Get(2)
+	assert.False(t, ok)
+
+	_, ok = m.GetKey("two")
+	assert.False(t, ok)
+
+	assert.Equal(t, 2, m.Size())
+}
+
+func TestBiMap_RemoveValue(t *testing.T) {
+	var m = sampleBiMap().RemoveValue("two")
+
+	_, ok := m.Get(2)
+	assert.False(t, ok)
+
+	_, ok = m.GetKey("two")
+	assert.False(t, ok)
+
+	assert.Equal(t, 2, m.Size())
+}
+
+func TestBiMap_VisitByKey(t *testing.T) {
+	var keys []interface{}
+	var values []interface{}
+
+	sampleBiMap().VisitByKey(func(key, value interface{}) bool {
+		keys = append(keys, key)
+		values = append(values, value)
+		return false
+	})
+
+	assert.Equal(t, []interface{}{1, 2, 3}, keys)
+	assert.Equal(t, []interface{}{"one", "two", "three"}, values)
+}
+
+func TestBiMap_VisitByValue(t *testing.T) {
+	var keys []interface{}
+	var values []interface{}
+
+	sampleBiMap().VisitByValue(func(key, value interface{}) bool {
+		keys = append(keys, key)
+		values = append(values, value)
+		return false
+	})
+
+	assert.Equal(t, []interface{}{1, 3, 2}, keys)
+	assert.Equal(t, []interface{}{"one", "three", "two"}, values)
+}
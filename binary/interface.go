@@ -83,6 +83,15 @@ func (c *InterfaceImpl) Update(with interface{}) {
 	return
 }
 
+// clone returns an independent copy of this InterfaceImpl. The copy
+// shares c's comparer and updater but not its value, so updating the
+// copy never affects c. PersistentTree relies on this to apply Update
+// without mutating a value that may still be reachable from an older
+// version of the tree.
+func (c *InterfaceImpl) clone() Interface {
+	return &InterfaceImpl{value: c.value, comp: c.comp, upd: c.upd}
+}
+
 // Generic returns an implementation of Interface.
 // This implementation's behavior is defined by parameters
 // passed to Generic at the time of its creation.
@@ -0,0 +1,231 @@
+package binary
+
+// An Iterator provides stateful traversal of a Tree, as an alternative
+// to the callback-based VisitInOrder/VisitInReverse. Unlike those, an
+// Iterator can be stopped and resumed freely by the caller, and can
+// move in either direction from wherever it is currently positioned.
+//
+// An Iterator is backed by an explicit stack of ancestor Nodes, so
+// Next and Prev are amortized O(1) and Seek is O(log n).
+//
+// An Iterator returned by Tree.Iterator starts out
+// unpositioned: Next moves it to the first item and Prev moves it to
+// the last, exactly as if First or Last had been called first. Once an
+// Iterator runs off one end via repeated Next or Prev calls, it stays
+// there - Next never wraps back around to the first item, and Prev
+// never wraps around to the last - until the other method, First,
+// Last, or Seek repositions it.
+type Iterator struct {
+	t     *Tree
+	stack []*Node
+
+	// beforeFirst and afterLast record whether the iterator has run
+	// off the corresponding end of the tree. Both are true while the
+	// iterator is unpositioned: when it is new, or after First or Last
+	// on an empty tree.
+	beforeFirst, afterLast bool
+}
+
+// Iterator returns a new, unpositioned Iterator over t.
+func (t *Tree) Iterator() *Iterator {
+	return &Iterator{t: t, beforeFirst: true, afterLast: true}
+}
+
+// pushLeft pushes n and then the left spine below it onto the stack, so
+// the smallest node of the subtree rooted at n ends up on top.
+func (it *Iterator) pushLeft(n *Node) {
+	for n != nil {
+		it.stack = append(it.stack, n)
+		n = n.left
+	}
+}
+
+// pushRight pushes n and then the right spine below it onto the stack,
+// so the largest node of the subtree rooted at n ends up on top.
+func (it *Iterator) pushRight(n *Node) {
+	for n != nil {
+		it.stack = append(it.stack, n)
+		n = n.right
+	}
+}
+
+// advance moves the stack from the current node to its in-order
+// successor, leaving the stack empty if there isn't one.
+func (it *Iterator) advance() {
+	var cur = it.stack[len(it.stack)-1]
+
+	if cur.right != nil {
+		it.pushLeft(cur.right)
+		return
+	}
+
+	for len(it.stack) > 0 {
+		var child = it.stack[len(it.stack)-1]
+		it.stack = it.stack[:len(it.stack)-1]
+
+		if len(it.stack) == 0 {
+			return
+		}
+
+		if it.stack[len(it.stack)-1].left == child {
+			return
+		}
+	}
+}
+
+// retreat moves the stack from the current node to its in-order
+// predecessor, leaving the stack empty if there isn't one.
+func (it *Iterator) retreat() {
+	var cur = it.stack[len(it.stack)-1]
+
+	if cur.left != nil {
+		it.pushRight(cur.left)
+		return
+	}
+
+	for len(it.stack) > 0 {
+		var child = it.stack[len(it.stack)-1]
+		it.stack = it.stack[:len(it.stack)-1]
+
+		if len(it.stack) == 0 {
+			return
+		}
+
+		if it.stack[len(it.stack)-1].right == child {
+			return
+		}
+	}
+}
+
+// Next moves the iterator to the next item in ascending order and
+// returns whether there was one to move to. If the iterator is
+// unpositioned, Next moves it to the first item, as First would.
+func (it *Iterator) Next() bool {
+	switch {
+	case it.beforeFirst:
+		it.beforeFirst, it.afterLast = false, false
+		it.pushLeft(it.t.root)
+	case it.afterLast:
+		return false
+	default:
+		it.advance()
+	}
+
+	if len(it.stack) == 0 {
+		it.afterLast = true
+		return false
+	}
+
+	return true
+}
+
+// Prev moves the iterator to the previous item in ascending order and
+// returns whether there was one to move to. If the iterator is
+// unpositioned, Prev moves it to the last item, as Last would.
+func (it *Iterator) Prev() bool {
+	switch {
+	case it.afterLast:
+		it.beforeFirst, it.afterLast = false, false
+		it.pushRight(it.t.root)
+	case it.beforeFirst:
+		return false
+	default:
+		it.retreat()
+	}
+
+	if len(it.stack) == 0 {
+		it.beforeFirst = true
+		return false
+	}
+
+	return true
+}
+
+// Node returns the Node the iterator is currently positioned at, or
+// nil if the iterator is unpositioned.
+func (it *Iterator) Node() *Node {
+	if len(it.stack) == 0 {
+		return nil
+	}
+
+	return it.stack[len(it.stack)-1]
+}
+
+// Value returns the value of the Node the iterator is currently
+// positioned at, or nil if the iterator is unpositioned.
+func (it *Iterator) Value() interface{} {
+	if n := it.Node(); n != nil {
+		return n.Value()
+	}
+
+	return nil
+}
+
+// First positions the iterator at the smallest item in the tree. After
+// First, Value and Node are valid immediately - there is no need to
+// call Next first.
+func (it *Iterator) First() {
+	it.stack = it.stack[:0]
+	it.beforeFirst, it.afterLast = false, false
+	it.pushLeft(it.t.root)
+
+	if len(it.stack) == 0 {
+		it.beforeFirst, it.afterLast = true, true
+	}
+}
+
+// Last positions the iterator at the largest item in the tree. After
+// Last, Value and Node are valid immediately - there is no need to
+// call Prev first.
+func (it *Iterator) Last() {
+	it.stack = it.stack[:0]
+	it.beforeFirst, it.afterLast = false, false
+	it.pushRight(it.t.root)
+
+	if len(it.stack) == 0 {
+		it.beforeFirst, it.afterLast = true, true
+	}
+}
+
+// Seek positions the iterator at the smallest item that is greater
+// than or equal to item, and reports whether that item is an exact
+// match. If no such item exists, the iterator ends up past the last
+// item (as if it had run off the end via Next), and Seek returns false.
+func (it *Iterator) Seek(item Interface) bool {
+	it.stack = it.stack[:0]
+	it.beforeFirst, it.afterLast = false, false
+
+	var cur, found = it.t.root, false
+
+	for cur != nil {
+		// Push every node on the descent, even ones smaller than item -
+		// Next and Prev need the real ancestor path, not just the
+		// shrinking set of candidates for the answer.
+		it.stack = append(it.stack, cur)
+
+		switch result := item.Compare(cur.Value()); {
+		case result < EQ:
+			cur = cur.left
+		case result > EQ:
+			cur = cur.right
+		default:
+			found = true
+			cur = nil
+		}
+	}
+
+	if !found {
+		// The descent pushed every ancestor it visited, including ones
+		// smaller than item. Pop back up to the first one that is
+		// actually >= item.
+		for len(it.stack) > 0 && item.Compare(it.stack[len(it.stack)-1].Value()) > EQ {
+			it.stack = it.stack[:len(it.stack)-1]
+		}
+	}
+
+	if len(it.stack) == 0 {
+		it.afterLast = true
+	}
+
+	return found
+}
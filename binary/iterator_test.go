@@ -0,0 +1,133 @@
+package binary
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func RangeTree() *Tree {
+	var tree = new(Tree)
+	for _, v := range []string{"M", "F", "T", "B", "H", "P", "X"} {
+		tree.Insert(String(v, false))
+	}
+	return tree
+}
+
+func TestIterator_Next(t *testing.T) {
+	var it = RangeTree().Iterator()
+	var want = []interface{}{"B", "F", "H", "M", "P", "T", "X"}
+	var got []interface{}
+
+	for it.Next() {
+		got = append(got, it.Value())
+	}
+
+	assert.Equal(t, want, got)
+	assert.False(t, it.Next())
+}
+
+func TestIterator_Prev(t *testing.T) {
+	var it = RangeTree().Iterator()
+	var want = []interface{}{"X", "T", "P", "M", "H", "F", "B"}
+	var got []interface{}
+
+	for it.Prev() {
+		got = append(got, it.Value())
+	}
+
+	assert.Equal(t, want, got)
+	assert.False(t, it.Prev())
+}
+
+func TestIterator_NextThenPrevReturnsToThePreviousItem(t *testing.T) {
+	var it = RangeTree().Iterator()
+
+	it.Next() // B
+	it.Next() // F
+	it.Next() // H
+
+	assert.Equal(t, "H", it.Value())
+	assert.True(t, it.Prev())
+	assert.Equal(t, "F", it.Value())
+}
+
+func TestIterator_PrevAfterRunningOffTheEndResumesAtTheLastItem(t *testing.T) {
+	var it = RangeTree().Iterator()
+
+	for it.Next() {
+	}
+
+	assert.True(t, it.Prev())
+	assert.Equal(t, "X", it.Value())
+}
+
+func TestIterator_FirstAndLast(t *testing.T) {
+	var it = RangeTree().Iterator()
+
+	it.First()
+	assert.Equal(t, "B", it.Value())
+
+	it.Last()
+	assert.Equal(t, "X", it.Value())
+}
+
+func TestIterator_Seek(t *testing.T) {
+	tt := []struct {
+		name      string
+		arg       string
+		wantFound bool
+		wantValue interface{}
+	}{
+		{"Exact match", "H", true, "H"},
+		{"Between items", "G", false, "H"},
+		{"Before first item", "A", false, "B"},
+		{"After last item", "Z", false, nil},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var it = RangeTree().Iterator()
+			var found = it.Seek(String(tc.arg, false))
+
+			assert.Equal(t, tc.wantFound, found)
+			assert.Equal(t, tc.wantValue, it.Value())
+		})
+	}
+}
+
+func TestIterator_RangeQueryUsingSeek(t *testing.T) {
+	var it = RangeTree().Iterator()
+	var want = []interface{}{"H", "M", "P"}
+	var got []interface{}
+
+	it.Seek(String("H", false))
+	for it.Node() != nil && it.Value().(string) <= "P" {
+		got = append(got, it.Value())
+		it.Next()
+	}
+
+	assert.Equal(t, want, got)
+}
+
+func TestIterator_NodeAndValueOnUnpositionedIterator(t *testing.T) {
+	var it = RangeTree().Iterator()
+
+	assert.Nil(t, it.Node())
+	assert.Nil(t, it.Value())
+}
+
+func TestIterator_OnEmptyTree(t *testing.T) {
+	var it = new(Tree).Iterator()
+
+	assert.False(t, it.Next())
+	assert.False(t, it.Prev())
+
+	it.First()
+	assert.Nil(t, it.Node())
+
+	it.Last()
+	assert.Nil(t, it.Node())
+
+	assert.False(t, it.Seek(String("A", false)))
+}
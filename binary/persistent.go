@@ -0,0 +1,466 @@
+package binary
+
+// A persistentNode is a node in a PersistentTree. Once a persistentNode
+// has been published as part of a tree, it is never mutated again:
+// every insert or remove produces new nodes along the path to the
+// change and shares every untouched subtree with the previous version.
+type persistentNode struct {
+	// left and right are pointers to the children of the node.
+	left, right *persistentNode
+
+	// value contains the Value for this node.
+	value Interface
+
+	// height is the height of the subtree rooted at this node, used to
+	// keep path length (and so the cost of each update) at O(log n).
+	height int8
+}
+
+// newPersistentNode builds a persistentNode wrapping value with the
+// given children, computing its height from theirs.
+func newPersistentNode(value Interface, left, right *persistentNode) *persistentNode {
+	return &persistentNode{
+		value:  value,
+		left:   left,
+		right:  right,
+		height: 1 + persistentMax8(persistentHeight(left), persistentHeight(right)),
+	}
+}
+
+// persistentHeight returns the height of n, treating a nil node as
+// having a height of 0.
+func persistentHeight(n *persistentNode) int8 {
+	if n == nil {
+		return 0
+	}
+
+	return n.height
+}
+
+// persistentMax8 returns the larger of a and b.
+func persistentMax8(a, b int8) int8 {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+// persistentBalanceFactor returns the difference between the heights of
+// n's left and right subtrees.
+func persistentBalanceFactor(n *persistentNode) int8 {
+	return persistentHeight(n.left) - persistentHeight(n.right)
+}
+
+// persistentRotateRight performs a right rotation around n, returning a
+// new subtree root. n itself is left untouched - only new nodes are
+// allocated along the rotated path.
+func persistentRotateRight(n *persistentNode) *persistentNode {
+	var l = n.left
+	return newPersistentNode(l.value, l.left, newPersistentNode(n.value, l.right, n.right))
+}
+
+// persistentRotateLeft performs a left rotation around n, returning a
+// new subtree root. n itself is left untouched - only new nodes are
+// allocated along the rotated path.
+func persistentRotateLeft(n *persistentNode) *persistentNode {
+	var r = n.right
+	return newPersistentNode(r.value, newPersistentNode(n.value, n.left, r.left), r.right)
+}
+
+// persistentRebalance returns a version of n that satisfies the AVL
+// balance invariant, rotating if necessary. n is never mutated; if a
+// rotation is needed, new nodes are returned in its place.
+func persistentRebalance(n *persistentNode) *persistentNode {
+	switch b := persistentBalanceFactor(n); {
+	case b > 1:
+		if persistentBalanceFactor(n.left) < 0 {
+			n = newPersistentNode(n.value, persistentRotateLeft(n.left), n.right)
+		}
+		return persistentRotateRight(n)
+	case b < -1:
+		if persistentBalanceFactor(n.right) > 0 {
+			n = newPersistentNode(n.value, n.left, persistentRotateRight(n.right))
+		}
+		return persistentRotateLeft(n)
+	default:
+		return n
+	}
+}
+
+// A PersistentTree is an applicative binary search tree: Insert and
+// Remove never modify the receiver. Instead, each returns a new
+// *PersistentTree that shares every subtree untouched by the change
+// with the tree it was derived from. This makes snapshotting, undo, and
+// concurrent readers of old versions safe without locking - nothing is
+// ever mutated out from under a reader holding an earlier *PersistentTree.
+//
+// Like AVLTree, a PersistentTree rebalances on every Insert and Remove,
+// so each update stays O(log n) in both time and the number of nodes
+// it allocates.
+type PersistentTree struct {
+	root *persistentNode
+	size int
+}
+
+// persistentRootOf returns t's root, treating a nil *PersistentTree as
+// an empty tree.
+func persistentRootOf(t *PersistentTree) *persistentNode {
+	if t == nil {
+		return nil
+	}
+
+	return t.root
+}
+
+// Size returns the number of items in the tree.
+func (t *PersistentTree) Size() int {
+	return t.size
+}
+
+// find locates the Interface stored in the tree that matches item, if
+// any.
+func (t *PersistentTree) find(item Interface) (found bool, value Interface) {
+	var cur = t.root
+
+	for cur != nil {
+		switch result := item.Compare(cur.value.Value()); {
+		case result < EQ:
+			cur = cur.left
+		case result > EQ:
+			cur = cur.right
+		default:
+			return true, cur.value
+		}
+	}
+
+	return false, nil
+}
+
+// Contains searches the tree for a given item and returns true if it is
+// found.
+func (t *PersistentTree) Contains(item Interface) bool {
+	found, _ := t.find(item)
+	return found
+}
+
+// Get retrieves an item from the tree.
+// If the item is not in the tree, it will return nil instead.
+func (t *PersistentTree) Get(item Interface) interface{} {
+	if found, value := t.find(item); found {
+		return value.Value()
+	}
+
+	return nil
+}
+
+// cloner is implemented by Interface values that can produce an
+// independent copy of themselves. PersistentTree uses it to apply
+// Update without mutating a value that an older version of the tree may
+// still be holding onto.
+type cloner interface {
+	clone() Interface
+}
+
+// clonedUpdate returns a copy of existing updated with with, leaving
+// existing itself untouched. If existing does not implement cloner -
+// which every Interface this package produces does - it falls back to
+// updating existing in place.
+func clonedUpdate(existing Interface, with interface{}) Interface {
+	var updated = existing
+	if c, ok := existing.(cloner); ok {
+		updated = c.clone()
+	}
+
+	updated.Update(with)
+	return updated
+}
+
+// persistentInsert returns a version of the subtree rooted at n with
+// item inserted. It assumes no value matching item is already present
+// in the subtree; PersistentTree.Insert handles matches itself before
+// ever calling this.
+func persistentInsert(n *persistentNode, item Interface) *persistentNode {
+	if n == nil {
+		return newPersistentNode(item, nil, nil)
+	}
+
+	if item.Compare(n.value.Value()) < EQ {
+		return persistentRebalance(newPersistentNode(n.value, persistentInsert(n.left, item), n.right))
+	}
+
+	return persistentRebalance(newPersistentNode(n.value, n.left, persistentInsert(n.right, item)))
+}
+
+// persistentReplace returns a version of the subtree rooted at n with
+// the value matching item swapped out for updated. Because updated
+// compares equal to the value it replaces, the shape of the subtree -
+// and so every node's height - is unchanged, and no rebalancing is
+// needed.
+func persistentReplace(n *persistentNode, item Interface, updated Interface) *persistentNode {
+	switch result := item.Compare(n.value.Value()); {
+	case result < EQ:
+		return newPersistentNode(n.value, persistentReplace(n.left, item, updated), n.right)
+	case result > EQ:
+		return newPersistentNode(n.value, n.left, persistentReplace(n.right, item, updated))
+	default:
+		return newPersistentNode(updated, n.left, n.right)
+	}
+}
+
+// Insert returns a new tree with item inserted. If an item matching it
+// already exists in the tree, the new tree's copy of that item has
+// Update called on it with item's value, exactly as Tree.Insert does -
+// except that the existing item, and every tree derived from it, are
+// left untouched.
+func (t *PersistentTree) Insert(item Interface) *PersistentTree {
+	if found, value := t.find(item); found {
+		var before = value.Value()
+		var updated = clonedUpdate(value, item.Value())
+
+		if updated.Compare(before) == EQ {
+			// The update didn't change where the value belongs in the
+			// tree, so we can just swap it in at its current position.
+			return &PersistentTree{root: persistentReplace(t.root, item, updated), size: t.size}
+		}
+
+		// The update moved the value to a different key, which could
+		// collide with a different value already at that key. Remove
+		// the old value - item can still find it, since the tree
+		// hasn't changed yet - and recurse through Insert so that a
+		// collision at the new key merges just as the original one did.
+		var root, _ = persistentRemove(t.root, item)
+		return (&PersistentTree{root: root, size: t.size - 1}).Insert(updated)
+	}
+
+	return &PersistentTree{root: persistentInsert(t.root, item), size: t.size + 1}
+}
+
+// persistentRemove returns a version of the subtree rooted at n with
+// item removed, along with whether item was found.
+func persistentRemove(n *persistentNode, item Interface) (root *persistentNode, removed bool) {
+	if n == nil {
+		return nil, false
+	}
+
+	switch result := item.Compare(n.value.Value()); {
+	case result < EQ:
+		left, removed := persistentRemove(n.left, item)
+		if !removed {
+			return n, false
+		}
+		return persistentRebalance(newPersistentNode(n.value, left, n.right)), true
+	case result > EQ:
+		right, removed := persistentRemove(n.right, item)
+		if !removed {
+			return n, false
+		}
+		return persistentRebalance(newPersistentNode(n.value, n.left, right)), true
+	}
+
+	if n.left == nil {
+		return n.right, true
+	}
+	if n.right == nil {
+		return n.left, true
+	}
+
+	// The node has two children, so instead of deleting it we replace
+	// it with its in-order successor - the left-most value of its right
+	// subtree - and remove the successor from the right subtree.
+	var succ = n.right
+	for succ.left != nil {
+		succ = succ.left
+	}
+
+	right, _ := persistentRemove(n.right, succ.value)
+	return persistentRebalance(newPersistentNode(succ.value, n.left, right)), true
+}
+
+// Remove returns a new tree with item removed, if it was present. If
+// item is not in the tree, the returned tree shares its entire
+// structure with t.
+func (t *PersistentTree) Remove(item Interface) *PersistentTree {
+	var root, removed = persistentRemove(t.root, item)
+	var size = t.size
+
+	if removed {
+		size--
+	}
+
+	return &PersistentTree{root: root, size: size}
+}
+
+// persistentVisitInOrder is used internally to visit all of the nodes
+// of a subtree in order, i.e. LVR.
+func persistentVisitInOrder(with VisitorFunc, subtreeRoot *persistentNode) bool {
+	if subtreeRoot == nil {
+		return Continue
+	}
+
+	if Done == persistentVisitInOrder(with, subtreeRoot.left) {
+		return Done
+	}
+
+	if Done == with(subtreeRoot.value.Value()) {
+		return Done
+	}
+
+	return persistentVisitInOrder(with, subtreeRoot.right)
+}
+
+// VisitInOrder visits the nodes of a PersistentTree in order.
+// At each node, it applies the visitorFunc to the
+// value of the node. VisitInOrder stops visiting
+// when the VisitorFunc returns Done ("true") after
+// visiting the node.
+func (t *PersistentTree) VisitInOrder(with VisitorFunc) *PersistentTree {
+	_ = persistentVisitInOrder(with, t.root)
+	return t
+}
+
+// persistentVisitInReverse is used internally to visit all the nodes of
+// a subtree in reverse order, i.e. RVL.
+func persistentVisitInReverse(with VisitorFunc, subtreeRoot *persistentNode) bool {
+	if subtreeRoot == nil {
+		return Continue
+	}
+
+	if Done == persistentVisitInReverse(with, subtreeRoot.right) {
+		return Done
+	}
+
+	if Done == with(subtreeRoot.value.Value()) {
+		return Done
+	}
+
+	return persistentVisitInReverse(with, subtreeRoot.left)
+}
+
+// VisitInReverse visits the nodes of a PersistentTree in postfix or
+// reverse order. At each node, it applies the visitorFunc to the
+// value of the node. VisitInReverse stops visiting
+// when the VisitorFunc returns Done ("true") after
+// visiting the node.
+func (t *PersistentTree) VisitInReverse(with VisitorFunc) *PersistentTree {
+	_ = persistentVisitInReverse(with, t.root)
+	return t
+}
+
+// A DiffOp describes how a value differs between two versions of a
+// PersistentTree.
+type DiffOp int
+
+const (
+	// Added indicates the value is present in the new tree but was not
+	// present in the old one.
+	Added DiffOp = iota
+	// Removed indicates the value was present in the old tree but is
+	// not present in the new one.
+	Removed
+)
+
+// visitAllValues applies op to every value in the subtree rooted at n,
+// via cb, in no particular order - it's only used by diffNodes once an
+// entire subtree is known to be one-sided.
+func visitAllValues(n *persistentNode, op DiffOp, cb func(DiffOp, interface{})) {
+	if n == nil {
+		return
+	}
+
+	cb(op, n.value.Value())
+	visitAllValues(n.left, op, cb)
+	visitAllValues(n.right, op, cb)
+}
+
+// split divides n into the values less than key, the node matching
+// key (or nil, if there isn't one), and the values greater than key.
+// It's the inverse of join, and, like join, it never mutates n or any
+// of its descendants - each level it actually needs to rearrange
+// produces a new node, while anything it doesn't touch is returned by
+// the same pointer it came in on.
+func split(n *persistentNode, key interface{}) (left, mid, right *persistentNode) {
+	if n == nil {
+		return nil, nil, nil
+	}
+
+	switch result := n.value.Compare(key); {
+	case result < EQ:
+		var rLeft, rMid, rRight = split(n.right, key)
+		return join(n.left, n, rLeft), rMid, rRight
+	case result > EQ:
+		var lLeft, lMid, lRight = split(n.left, key)
+		return lLeft, lMid, join(lRight, n, n.right)
+	default:
+		return n.left, n, n.right
+	}
+}
+
+// join builds a subtree holding every value in left, mid (if mid isn't
+// nil), and right. It's used as scratch structure for split, so unlike
+// persistentInsert/persistentRemove it makes no attempt to stay
+// balanced - the result is only ever walked by diffNodes, never stored
+// in a PersistentTree.
+func join(left, mid, right *persistentNode) *persistentNode {
+	if mid == nil {
+		return joinPair(left, right)
+	}
+
+	return &persistentNode{left: left, value: mid.value, right: right}
+}
+
+// joinPair is join's case for when there is no middle value.
+func joinPair(left, right *persistentNode) *persistentNode {
+	switch {
+	case left == nil:
+		return right
+	case right == nil:
+		return left
+	default:
+		return &persistentNode{left: left.left, value: left.value, right: joinPair(left.right, right)}
+	}
+}
+
+// diffNodes walks old and new together, calling cb for every value
+// that differs between them. It prunes whenever the two sides are the
+// same *persistentNode - which is safe, since persistentNodes are
+// never mutated once published - so for two versions of a tree that
+// mostly still share structure after a handful of edits, it only does
+// work proportional to the edits (and the path to them) rather than
+// the size of the whole tree.
+func diffNodes(old, new *persistentNode, cb func(DiffOp, interface{})) {
+	if old == new {
+		return
+	}
+
+	if old == nil {
+		visitAllValues(new, Added, cb)
+		return
+	}
+
+	if new == nil {
+		visitAllValues(old, Removed, cb)
+		return
+	}
+
+	var newLeft, newMid, newRight = split(new, old.value.Value())
+
+	diffNodes(old.left, newLeft, cb)
+
+	switch {
+	case newMid == nil:
+		cb(Removed, old.value.Value())
+	case old.value.Value() != newMid.value.Value():
+		cb(Removed, old.value.Value())
+		cb(Added, newMid.value.Value())
+	}
+
+	diffNodes(old.right, newRight, cb)
+}
+
+// Diff reports how new differs from old, calling cb once for every
+// value that was Added or Removed between the two versions. old may be
+// nil, in which case every value in new is reported as Added.
+func Diff(old, new *PersistentTree, cb func(op DiffOp, value interface{})) {
+	diffNodes(persistentRootOf(old), persistentRootOf(new), cb)
+}
@@ -0,0 +1,224 @@
+package binary
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func SamplePersistentTree() *PersistentTree {
+	return new(PersistentTree).
+		Insert(String("M", false)).
+		Insert(String("L", false)).
+		Insert(String("R", false))
+}
+
+func TestPersistentTree_VisitInOrder(t *testing.T) {
+	var buf = new(bytes.Buffer)
+	SamplePersistentTree().VisitInOrder(PrintNodeTo(buf))
+
+	want := "L\nM\nR\n"
+	got := buf.String()
+
+	assert.Equal(t, want, got)
+}
+
+func TestPersistentTree_VisitInReverse(t *testing.T) {
+	var buf = new(bytes.Buffer)
+	SamplePersistentTree().VisitInReverse(PrintNodeTo(buf))
+
+	want := "R\nM\nL\n"
+	got := buf.String()
+
+	assert.Equal(t, want, got)
+}
+
+func TestPersistentTree_Contains(t *testing.T) {
+	tt := []struct {
+		name string
+		arg  string
+		want bool
+	}{
+		{"Exists", "L", true},
+		{"Not Exists", "Foo", false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var want = tc.want
+			var got = SamplePersistentTree().Contains(String(tc.arg, false))
+
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestPersistentTree_Get(t *testing.T) {
+	tt := []struct {
+		name string
+		arg  string
+		want interface{}
+	}{
+		{"Exists", "R", "R"},
+		{"Not Exists", "Foo", nil},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var want = tc.want
+			var got = SamplePersistentTree().Get(String(tc.arg, true))
+
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestPersistentTree_Size(t *testing.T) {
+	var tree = SamplePersistentTree()
+
+	assert.Equal(t, 3, tree.Size())
+	assert.Equal(t, 3, tree.Insert(String("M", false)).Size())
+	assert.Equal(t, 4, tree.Insert(String("Q", false)).Size())
+	assert.Equal(t, 2, tree.Remove(String("L", false)).Size())
+}
+
+func TestPersistentTree_InsertDoesNotMutateReceiver(t *testing.T) {
+	var before = SamplePersistentTree()
+	var after = before.Insert(String("Q", false))
+
+	var buf = new(bytes.Buffer)
+	before.VisitInOrder(PrintNodeTo(buf))
+	assert.Equal(t, "L\nM\nR\n", buf.String())
+
+	buf = new(bytes.Buffer)
+	after.VisitInOrder(PrintNodeTo(buf))
+	assert.Equal(t, "L\nM\nQ\nR\n", buf.String())
+}
+
+func TestPersistentTree_RemoveDoesNotMutateReceiver(t *testing.T) {
+	var before = SamplePersistentTree()
+	var after = before.Remove(String("L", false))
+
+	var buf = new(bytes.Buffer)
+	before.VisitInOrder(PrintNodeTo(buf))
+	assert.Equal(t, "L\nM\nR\n", buf.String())
+
+	buf = new(bytes.Buffer)
+	after.VisitInOrder(PrintNodeTo(buf))
+	assert.Equal(t, "M\nR\n", buf.String())
+}
+
+func TestPersistentTree_InsertUpdatesExistingNodeWithoutMutatingOldVersion(t *testing.T) {
+	var comparer = func(this, to interface{}) int {
+		return this.(int) - to.(int)
+	}
+	var updater = func(this, with interface{}) interface{} {
+		return this.(int) + 1
+	}
+	var item = func(i int) *InterfaceImpl {
+		return Generic(i, comparer, updater)
+	}
+
+	var before = new(PersistentTree).Insert(item(0))
+	var after = before.Insert(item(0))
+
+	assert.Equal(t, 0, before.Get(Int(0)))
+	assert.Nil(t, after.Get(Int(0)))
+	assert.Equal(t, 1, after.Get(Int(1)))
+}
+
+func TestPersistentTree_InsertChainOfUpdates(t *testing.T) {
+	var comparer = func(this, to interface{}) int {
+		return this.(int) - to.(int)
+	}
+
+	var updater = func(this, with interface{}) interface{} {
+		return this.(int) + 1
+	}
+
+	var item = func(i int) *InterfaceImpl {
+		return Generic(i, comparer, updater)
+	}
+
+	var buf = new(bytes.Buffer)
+	var tree = new(PersistentTree).
+		Insert(item(0)).
+		Insert(item(1)).
+		Insert(item(2))
+	tree.VisitInOrder(PrintNodeTo(buf))
+
+	var want, got = "0\n1\n2\n", buf.String()
+	require.Equal(t, want, got)
+
+	buf = new(bytes.Buffer)
+	tree = tree.Insert(item(0))
+	tree.VisitInOrder(PrintNodeTo(buf))
+
+	want, got = "3\n", buf.String()
+	assert.Equal(t, want, got)
+}
+
+func TestDiff(t *testing.T) {
+	var before = new(PersistentTree).
+		Insert(String("L", false)).
+		Insert(String("M", false)).
+		Insert(String("R", false))
+
+	var after = before.
+		Insert(String("Q", false)).
+		Remove(String("L", false))
+
+	var added, removed []interface{}
+	Diff(before, after, func(op DiffOp, value interface{}) {
+		switch op {
+		case Added:
+			added = append(added, value)
+		case Removed:
+			removed = append(removed, value)
+		}
+	})
+
+	assert.ElementsMatch(t, []interface{}{"Q"}, added)
+	assert.ElementsMatch(t, []interface{}{"L"}, removed)
+}
+
+func TestDiff_NilOldTreeReportsEveryValueAsAdded(t *testing.T) {
+	var after = SamplePersistentTree()
+
+	var added []interface{}
+	Diff(nil, after, func(op DiffOp, value interface{}) {
+		assert.Equal(t, Added, op)
+		added = append(added, value)
+	})
+
+	assert.ElementsMatch(t, []interface{}{"L", "M", "R"}, added)
+}
+
+// TestDiff_OneInsertIntoALargeTreePrunesSharedSubtrees diffs two
+// versions of a large PersistentTree that differ by a single Insert, and
+// so share every subtree off that Insert's path, and checks that Diff
+// reports the one added value and nothing else. It checks what Diff
+// reports, not how many nodes diffNodes visits.
+func TestDiff_OneInsertIntoALargeTreePrunesSharedSubtrees(t *testing.T) {
+	var before = new(PersistentTree)
+	for i := 0; i < 50000; i++ {
+		before = before.Insert(Int(i))
+	}
+
+	var after = before.Insert(Int(50001))
+
+	var added, removed []interface{}
+	Diff(before, after, func(op DiffOp, value interface{}) {
+		switch op {
+		case Added:
+			added = append(added, value)
+		case Removed:
+			removed = append(removed, value)
+		}
+	})
+
+	assert.Equal(t, []interface{}{50001}, added)
+	assert.Empty(t, removed)
+}
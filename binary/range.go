@@ -0,0 +1,151 @@
+package binary
+
+// Range visits every item in the tree whose key falls within [lo, hi]
+// - or, if inclusive is false, strictly between lo and hi - in
+// ascending order, descending only into subtrees that could contain a
+// qualifying key rather than scanning the whole tree. At each
+// qualifying item, it applies visit, stopping early if visit returns
+// Done ("true").
+func (t *Tree) Range(lo, hi Interface, inclusive bool, visit VisitorFunc) *Tree {
+	_ = rangeVisit(t.root, lo, hi, inclusive, visit)
+	return t
+}
+
+// rangeVisit is used internally to visit the Nodes of a subtree whose
+// values fall within [lo, hi] (or (lo, hi) if !inclusive), in order.
+func rangeVisit(n *Node, lo, hi Interface, inclusive bool, visit VisitorFunc) bool {
+	if n == nil {
+		return Continue
+	}
+
+	var cmpLo, cmpHi = lo.Compare(n.Value()), hi.Compare(n.Value())
+
+	// The left subtree holds only smaller values, so it can only
+	// contain a qualifying key if n itself isn't already below lo.
+	if cmpLo <= EQ {
+		if Done == rangeVisit(n.left, lo, hi, inclusive, visit) {
+			return Done
+		}
+	}
+
+	var within bool
+	if inclusive {
+		within = cmpLo <= EQ && cmpHi >= EQ
+	} else {
+		within = cmpLo < EQ && cmpHi > EQ
+	}
+
+	if within && Done == visit(n.Value()) {
+		return Done
+	}
+
+	// Symmetrically, the right subtree holds only larger values, so
+	// it's only worth descending into if n isn't already above hi.
+	if cmpHi >= EQ {
+		return rangeVisit(n.right, lo, hi, inclusive, visit)
+	}
+
+	return Continue
+}
+
+// Min returns the smallest item in the tree, or nil if the tree is
+// empty.
+func (t *Tree) Min() interface{} {
+	if t.root == nil {
+		return nil
+	}
+
+	var cur = t.root
+	for cur.left != nil {
+		cur = cur.left
+	}
+
+	return cur.Value()
+}
+
+// Max returns the largest item in the tree, or nil if the tree is
+// empty.
+func (t *Tree) Max() interface{} {
+	if t.root == nil {
+		return nil
+	}
+
+	var cur = t.root
+	for cur.right != nil {
+		cur = cur.right
+	}
+
+	return cur.Value()
+}
+
+// Floor returns the largest item in the tree that is less than or
+// equal to item, or nil if there is none.
+func (t *Tree) Floor(item Interface) interface{} {
+	var cur, best = t.root, (*Node)(nil)
+
+	for cur != nil {
+		switch result := item.Compare(cur.Value()); {
+		case result < EQ:
+			cur = cur.left
+		case result > EQ:
+			best = cur
+			cur = cur.right
+		default:
+			return cur.Value()
+		}
+	}
+
+	if best == nil {
+		return nil
+	}
+
+	return best.Value()
+}
+
+// Ceiling returns the smallest item in the tree that is greater than
+// or equal to item, or nil if there is none.
+func (t *Tree) Ceiling(item Interface) interface{} {
+	var cur, best = t.root, (*Node)(nil)
+
+	for cur != nil {
+		switch result := item.Compare(cur.Value()); {
+		case result < EQ:
+			best = cur
+			cur = cur.left
+		case result > EQ:
+			cur = cur.right
+		default:
+			return cur.Value()
+		}
+	}
+
+	if best == nil {
+		return nil
+	}
+
+	return best.Value()
+}
+
+// FromSorted builds a new Tree from items, which must already be
+// sorted in ascending order, in O(n) by recursively picking the middle
+// element of each slice as the subtree root. The resulting tree is
+// perfectly balanced.
+func FromSorted(items []Interface) *Tree {
+	return &Tree{root: buildBalanced(items)}
+}
+
+// buildBalanced recursively builds a balanced subtree from a sorted
+// slice of items.
+func buildBalanced(items []Interface) *Node {
+	if len(items) == 0 {
+		return nil
+	}
+
+	var mid = len(items) / 2
+
+	return &Node{
+		value: items[mid],
+		left:  buildBalanced(items[:mid]),
+		right: buildBalanced(items[mid+1:]),
+	}
+}
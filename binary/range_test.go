@@ -0,0 +1,118 @@
+package binary
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTree_Range(t *testing.T) {
+	tt := []struct {
+		name      string
+		lo, hi    string
+		inclusive bool
+		want      []interface{}
+	}{
+		{"Inclusive", "F", "P", true, []interface{}{"F", "H", "M", "P"}},
+		{"Exclusive", "F", "P", false, []interface{}{"H", "M"}},
+		{"Whole tree", "A", "Z", true, []interface{}{"B", "F", "H", "M", "P", "T", "X"}},
+		{"No matches", "I", "L", true, nil},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []interface{}
+
+			RangeTree().Range(String(tc.lo, false), String(tc.hi, false), tc.inclusive, func(value interface{}) bool {
+				got = append(got, value)
+				return Continue
+			})
+
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestTree_Range_StopsEarly(t *testing.T) {
+	var got []interface{}
+
+	RangeTree().Range(String("B", false), String("X", false), true, func(value interface{}) bool {
+		got = append(got, value)
+		return value == "H"
+	})
+
+	assert.Equal(t, []interface{}{"B", "F", "H"}, got)
+}
+
+func TestTree_MinAndMax(t *testing.T) {
+	var tree = RangeTree()
+
+	assert.Equal(t, "B", tree.Min())
+	assert.Equal(t, "X", tree.Max())
+}
+
+func TestTree_MinAndMax_OnEmptyTree(t *testing.T) {
+	var tree = new(Tree)
+
+	assert.Nil(t, tree.Min())
+	assert.Nil(t, tree.Max())
+}
+
+func TestTree_Floor(t *testing.T) {
+	tt := []struct {
+		name string
+		arg  string
+		want interface{}
+	}{
+		{"Exact match", "M", "M"},
+		{"Between items", "N", "M"},
+		{"Before first item", "A", nil},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, RangeTree().Floor(String(tc.arg, false)))
+		})
+	}
+}
+
+func TestTree_Ceiling(t *testing.T) {
+	tt := []struct {
+		name string
+		arg  string
+		want interface{}
+	}{
+		{"Exact match", "M", "M"},
+		{"Between items", "I", "M"},
+		{"After last item", "Z", nil},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, RangeTree().Ceiling(String(tc.arg, false)))
+		})
+	}
+}
+
+func TestFromSorted(t *testing.T) {
+	var items = make([]Interface, 0, 7)
+	for _, v := range []string{"B", "F", "H", "M", "P", "T", "X"} {
+		items = append(items, String(v, false))
+	}
+
+	var tree = FromSorted(items)
+
+	var got []interface{}
+	tree.VisitInOrder(func(value interface{}) bool {
+		got = append(got, value)
+		return Continue
+	})
+
+	assert.Equal(t, []interface{}{"B", "F", "H", "M", "P", "T", "X"}, got)
+	assert.Equal(t, "M", tree.root.Value())
+}
+
+func TestFromSorted_Empty(t *testing.T) {
+	var tree = FromSorted(nil)
+	assert.Nil(t, tree.Min())
+}
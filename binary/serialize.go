@@ -0,0 +1,243 @@
+package binary
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Markers used to frame a preorder stream of Nodes: nilMarker records
+// an absent child, and nodeMarker introduces a value followed by its
+// left and right subtrees.
+const (
+	nilMarker byte = iota
+	nodeMarker
+)
+
+// An Encoder writes a single value's bytes to w, for use with
+// Tree.WriteTo.
+type Encoder func(v interface{}, w io.Writer) error
+
+// A Decoder reads and returns a single value from r, for use with
+// Tree.ReadFrom.
+type Decoder func(r io.Reader) (interface{}, error)
+
+func writeMarker(w io.Writer, marker byte) error {
+	_, err := w.Write([]byte{marker})
+	return err
+}
+
+func readMarker(r io.Reader) (byte, error) {
+	var buf [1]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return 0, err
+	}
+
+	return buf[0], nil
+}
+
+// WriteTo writes t to w as a preorder stream of its values, framed by
+// sentinel markers for nil children, using enc to encode each value.
+// Because the stream preserves t's exact shape, ReadFrom can
+// reconstruct it in O(n) without re-comparing any values, which makes
+// WriteTo/ReadFrom suitable for streaming large trees without loading
+// them into memory all at once.
+func (t *Tree) WriteTo(w io.Writer, enc Encoder) error {
+	return writeNode(w, t.root, enc)
+}
+
+// writeNode is used internally to write the preorder stream for the
+// subtree rooted at n.
+func writeNode(w io.Writer, n *Node, enc Encoder) error {
+	if n == nil {
+		return writeMarker(w, nilMarker)
+	}
+
+	if err := writeMarker(w, nodeMarker); err != nil {
+		return err
+	}
+
+	if err := enc(n.Value(), w); err != nil {
+		return err
+	}
+
+	if err := writeNode(w, n.left, enc); err != nil {
+		return err
+	}
+
+	return writeNode(w, n.right, enc)
+}
+
+// ReadFrom replaces t's contents with a tree read from r, which must
+// hold a stream written by WriteTo. dec decodes each value, and cmp
+// and upd are used to rewrap each decoded value with Generic, exactly
+// as Tree.Insert would have wrapped it originally.
+//
+// r comes last, rather than first as with WriteTo, so that ReadFrom's
+// signature doesn't accidentally collide with io.ReaderFrom.
+func (t *Tree) ReadFrom(dec Decoder, cmp CompareFunc, upd UpdateFunc, r io.Reader) error {
+	var root, err = readNode(r, dec, cmp, upd)
+	if err != nil {
+		return err
+	}
+
+	t.root = root
+	return nil
+}
+
+// readNode is used internally to read the preorder stream written by
+// writeNode back into a subtree.
+func readNode(r io.Reader, dec Decoder, cmp CompareFunc, upd UpdateFunc) (*Node, error) {
+	var marker, err = readMarker(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if marker == nilMarker {
+		return nil, nil
+	}
+
+	var value interface{}
+	if value, err = dec(r); err != nil {
+		return nil, err
+	}
+
+	var n = &Node{value: Generic(value, cmp, upd)}
+
+	if n.left, err = readNode(r, dec, cmp, upd); err != nil {
+		return nil, err
+	}
+
+	if n.right, err = readNode(r, dec, cmp, upd); err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// Type tags used by MarshalBinary/UnmarshalBinary to tell int and
+// string values apart in the encoded stream.
+const (
+	binaryInt byte = iota + 1
+	binaryString
+)
+
+// MarshalBinary encodes t as a compact preorder stream, implementing
+// encoding.BinaryMarshaler. Unlike WriteTo, MarshalBinary can't accept
+// a caller-supplied Encoder or CompareFunc - its signature is fixed by
+// encoding.BinaryMarshaler - so it only supports trees built from the
+// int and string values that Int and String wrap. For anything else,
+// use WriteTo with an Encoder of your own.
+func (t *Tree) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := t.WriteTo(&buf, encodeBuiltinValue); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes data produced by MarshalBinary, implementing
+// encoding.BinaryUnmarshaler. It reconstructs t's exact original shape
+// in O(n) without re-comparing any values. See MarshalBinary for the
+// set of value types it supports.
+func (t *Tree) UnmarshalBinary(data []byte) error {
+	return t.ReadFrom(decodeBuiltinValue, builtinCompare, nil, bytes.NewReader(data))
+}
+
+// encodeBuiltinValue is the Encoder used by MarshalBinary.
+func encodeBuiltinValue(v interface{}, w io.Writer) error {
+	switch value := v.(type) {
+	case int:
+		if err := writeMarker(w, binaryInt); err != nil {
+			return err
+		}
+
+		return binary.Write(w, binary.BigEndian, int64(value))
+	case string:
+		if err := writeMarker(w, binaryString); err != nil {
+			return err
+		}
+
+		if err := binary.Write(w, binary.BigEndian, uint32(len(value))); err != nil {
+			return err
+		}
+
+		_, err := io.WriteString(w, value)
+		return err
+	default:
+		return fmt.Errorf("binary: cannot marshal value of type %T; use WriteTo with your own Encoder instead", v)
+	}
+}
+
+// decodeBuiltinValue is the Decoder used by UnmarshalBinary.
+func decodeBuiltinValue(r io.Reader) (interface{}, error) {
+	var tag, err = readMarker(r)
+	if err != nil {
+		return nil, err
+	}
+
+	switch tag {
+	case binaryInt:
+		var value int64
+		if err := binary.Read(r, binary.BigEndian, &value); err != nil {
+			return nil, err
+		}
+
+		return int(value), nil
+	case binaryString:
+		var length uint32
+		if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+			return nil, err
+		}
+
+		var buf = make([]byte, length)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+
+		return string(buf), nil
+	default:
+		return nil, fmt.Errorf("binary: unknown value tag %d", tag)
+	}
+}
+
+// builtinCompare is the CompareFunc used by UnmarshalBinary to rewrap
+// the int and string values encodeBuiltinValue knows how to write,
+// mirroring the comparers Int and String(v, false) use.
+func builtinCompare(this, to interface{}) int {
+	switch value := this.(type) {
+	case int:
+		return value - to.(int)
+	case string:
+		var str = to.(string)
+		switch {
+		case value < str:
+			return LT
+		case value > str:
+			return GT
+		default:
+			return EQ
+		}
+	default:
+		return EQ
+	}
+}
+
+// MarshalJSON encodes t's values as a JSON array, in ascending order,
+// implementing json.Marshaler. There is no matching UnmarshalJSON:
+// rebuilding a tree needs a comparer, which can't be recovered from
+// JSON, so MarshalJSON is meant for consumers outside Go rather than
+// round-tripping through this package.
+func (t *Tree) MarshalJSON() ([]byte, error) {
+	var values = make([]interface{}, 0)
+
+	t.VisitInOrder(func(v interface{}) bool {
+		values = append(values, v)
+		return Continue
+	})
+
+	return json.Marshal(values)
+}
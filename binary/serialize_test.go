@@ -0,0 +1,85 @@
+package binary
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTree_WriteToAndReadFrom(t *testing.T) {
+	var original = RangeTree()
+
+	var buf bytes.Buffer
+	require.NoError(t, original.WriteTo(&buf, encodeBuiltinValue))
+
+	var restored = new(Tree)
+	require.NoError(t, restored.ReadFrom(decodeBuiltinValue, builtinCompare, nil, &buf))
+
+	var want []interface{}
+	original.VisitInOrder(func(v interface{}) bool {
+		want = append(want, v)
+		return Continue
+	})
+
+	var got []interface{}
+	restored.VisitInOrder(func(v interface{}) bool {
+		got = append(got, v)
+		return Continue
+	})
+
+	assert.Equal(t, want, got)
+	assert.Equal(t, original.root.Value(), restored.root.Value())
+	assert.Equal(t, original.root.left.Value(), restored.root.left.Value())
+	assert.Equal(t, original.root.right.Value(), restored.root.right.Value())
+}
+
+func TestTree_MarshalBinaryAndUnmarshalBinary(t *testing.T) {
+	var original = new(Tree)
+	for _, v := range []int{5, 3, 8, 1, 4, 7, 9} {
+		original.Insert(Int(v))
+	}
+
+	var data, err = original.MarshalBinary()
+	require.NoError(t, err)
+
+	var restored = new(Tree)
+	require.NoError(t, restored.UnmarshalBinary(data))
+
+	var want []interface{}
+	original.VisitInOrder(func(v interface{}) bool {
+		want = append(want, v)
+		return Continue
+	})
+
+	var got []interface{}
+	restored.VisitInOrder(func(v interface{}) bool {
+		got = append(got, v)
+		return Continue
+	})
+
+	assert.Equal(t, want, got)
+	assert.True(t, restored.Contains(Int(7)))
+}
+
+func TestTree_MarshalBinary_RejectsUnsupportedValueTypes(t *testing.T) {
+	var tree = new(Tree)
+	tree.Insert(Generic(3.14, func(this, to interface{}) int {
+		return 0
+	}, nil))
+
+	_, err := tree.MarshalBinary()
+	assert.Error(t, err)
+}
+
+func TestTree_MarshalJSON(t *testing.T) {
+	var data, err = RangeTree().MarshalJSON()
+	require.NoError(t, err)
+
+	var got []string
+	require.NoError(t, json.Unmarshal(data, &got))
+
+	assert.Equal(t, []string{"B", "F", "H", "M", "P", "T", "X"}, got)
+}